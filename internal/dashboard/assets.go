@@ -0,0 +1,69 @@
+package dashboard
+
+import (
+	"embed"
+	"html/template"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// pageData is the root data value passed to every page template: Args, Loc
+// and CSRFToken are available to the base layout and every content
+// template, Page holds the handler-specific data for the content template
+// itself.
+type pageData struct {
+	Args Args
+	Loc  *Localizer
+	Page interface{}
+
+	// RefreshSeconds, if non-zero, adds a meta-refresh tag to the page so
+	// it auto-updates (used by the /status page's live view).
+	RefreshSeconds int
+
+	// CSRFToken is embedded by every state-changing form as a hidden
+	// "csrf_token" input; see csrf.go.
+	CSRFToken string
+}
+
+// render parses the base layout together with the named content template
+// and executes it against data, wrapped with the request's Args and
+// negotiated Localizer.
+func (db *Dashboard) render(w http.ResponseWriter, r *http.Request, page string, data interface{}) {
+	db.renderWithRefresh(w, r, page, data, 0)
+}
+
+// renderWithRefresh is like render, but adds a meta-refresh tag so the
+// browser reloads the page every refreshSeconds.
+func (db *Dashboard) renderWithRefresh(w http.ResponseWriter, r *http.Request, page string, data interface{}, refreshSeconds int) {
+	tmpl, err := template.New("base.html").ParseFS(templateFS, "templates/base.html", "templates/"+page)
+	if err != nil {
+		log.Error().Err(err).Str("page", page).Msg("Got error when parsing template")
+		http.Error(w, localizerFor(r).T("error.internal"), http.StatusInternalServerError)
+		return
+	}
+
+	err = tmpl.ExecuteTemplate(w, "base.html", pageData{
+		Args:           db.args,
+		Loc:            localizerFor(r),
+		Page:           data,
+		RefreshSeconds: refreshSeconds,
+		CSRFToken:      db.csrfToken(w, r),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("page", page).Msg("Got error when executing template")
+		http.Error(w, localizerFor(r).T("error.internal"), http.StatusInternalServerError)
+	}
+}
+
+// staticHandler serves the dashboard's embedded CSS and other static
+// assets under /static/.
+func (db *Dashboard) staticHandler() http.Handler {
+	return http.FileServer(http.FS(staticFS))
+}
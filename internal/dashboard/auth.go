@@ -0,0 +1,142 @@
+package dashboard
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Role is a coarse-grained permission level within the dashboard itself, as
+// opposed to a permission within SpiceDB's own permissions system.
+type Role string
+
+const (
+	// RoleViewer can see read-only views: schema, status and the token
+	// inspector's listing.
+	RoleViewer Role = "viewer"
+
+	// RoleAdmin can additionally use the write endpoints: the schema
+	// editor, relationship management and the permission playground.
+	RoleAdmin Role = "admin"
+)
+
+// Principal is the authenticated caller of a dashboard request.
+type Principal struct {
+	// Subject identifies the caller, for logging and auditing; its format
+	// is specific to the Authenticator that produced it.
+	Subject string
+
+	// Role is the caller's dashboard role.
+	Role Role
+}
+
+// ErrUnauthenticated is returned by an Authenticator when the request does
+// not carry valid credentials.
+var ErrUnauthenticated = errors.New("dashboard: unauthenticated")
+
+// Authenticator authenticates an incoming dashboard HTTP request and
+// determines the calling Principal's Role.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// noAuthenticator grants every request the admin role; it backs
+// Args.AuthMode "none", which preserves the dashboard's historical
+// behavior of trusting anyone who can reach it.
+type noAuthenticator struct{}
+
+func (noAuthenticator) Authenticate(*http.Request) (*Principal, error) {
+	return &Principal{Subject: "anonymous", Role: RoleAdmin}, nil
+}
+
+// denyAllAuthenticator rejects every request; it backs the dashboard
+// whenever the configured Authenticator failed to initialize, so a
+// misconfiguration (for example, an unreachable OIDC issuer) fails closed
+// instead of falling back to trusting every caller.
+type denyAllAuthenticator struct{}
+
+func (denyAllAuthenticator) Authenticate(*http.Request) (*Principal, error) {
+	return nil, ErrUnauthenticated
+}
+
+// PresharedKeyAuthenticator authenticates requests carrying the same
+// preshared key used to authenticate to the gRPC endpoint, as an
+// "Authorization: Bearer <key>" header. A valid key is always granted the
+// admin role, since presenting it implies the same trust level as talking to
+// the gRPC API directly.
+type PresharedKeyAuthenticator struct {
+	Key string
+}
+
+func (a *PresharedKeyAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	const prefix = "Bearer "
+
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return nil, ErrUnauthenticated
+	}
+
+	presented := strings.TrimPrefix(header, prefix)
+	if subtle.ConstantTimeCompare([]byte(presented), []byte(a.Key)) != 1 {
+		return nil, ErrUnauthenticated
+	}
+
+	return &Principal{Subject: "preshared-key", Role: RoleAdmin}, nil
+}
+
+// MTLSAuthenticator authenticates requests by the common name of the client
+// certificate presented during the TLS handshake. The dashboard's HTTP
+// server must be configured to request and verify client certificates for
+// this authenticator to see any.
+type MTLSAuthenticator struct {
+	// AdminCommonNames is the set of client certificate common names that
+	// are granted the admin role; all other verified certificates are
+	// granted the viewer role.
+	AdminCommonNames map[string]struct{}
+}
+
+func (a *MTLSAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil, ErrUnauthenticated
+	}
+
+	cn := r.TLS.PeerCertificates[0].Subject.CommonName
+
+	role := RoleViewer
+	if _, ok := a.AdminCommonNames[cn]; ok {
+		role = RoleAdmin
+	}
+
+	return &Principal{Subject: cn, Role: role}, nil
+}
+
+// requireRole wraps handler so that it only runs for requests authenticated
+// to at least the given role, returning 401 or 403 otherwise. RoleAdmin
+// requests always satisfy a RoleViewer requirement.
+//
+// A nil db.authenticator is treated as authenticating nobody rather than as
+// "no authentication required": NewDashboard guarantees authenticator is
+// always set (configureAuthenticator falls back to denyAllAuthenticator on
+// error), so this is defense in depth, not the normal path.
+func (db *Dashboard) requireRole(role Role, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if db.authenticator == nil {
+			http.Error(w, "unauthenticated", http.StatusUnauthorized)
+			return
+		}
+
+		principal, err := db.authenticator.Authenticate(r)
+		if err != nil {
+			http.Error(w, "unauthenticated", http.StatusUnauthorized)
+			return
+		}
+
+		if role == RoleAdmin && principal.Role != RoleAdmin {
+			http.Error(w, "forbidden: admin role required", http.StatusForbidden)
+			return
+		}
+
+		handler(w, r)
+	}
+}
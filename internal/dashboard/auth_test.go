@@ -0,0 +1,142 @@
+package dashboard
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPresharedKeyAuthenticator(t *testing.T) {
+	a := &PresharedKeyAuthenticator{Key: "correct-key"}
+
+	t.Run("valid key", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer correct-key")
+
+		principal, err := a.Authenticate(r)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if principal.Role != RoleAdmin {
+			t.Errorf("expected RoleAdmin, got %v", principal.Role)
+		}
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer wrong-key")
+
+		if _, err := a.Authenticate(r); err != ErrUnauthenticated {
+			t.Errorf("expected ErrUnauthenticated, got %v", err)
+		}
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		if _, err := a.Authenticate(r); err != ErrUnauthenticated {
+			t.Errorf("expected ErrUnauthenticated, got %v", err)
+		}
+	})
+}
+
+func TestMTLSAuthenticator(t *testing.T) {
+	a := &MTLSAuthenticator{AdminCommonNames: map[string]struct{}{"admin-cn": {}}}
+
+	t.Run("no TLS connection state", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		if _, err := a.Authenticate(r); err != ErrUnauthenticated {
+			t.Errorf("expected ErrUnauthenticated, got %v", err)
+		}
+	})
+
+	t.Run("no client certificate presented", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.TLS = &tls.ConnectionState{}
+
+		if _, err := a.Authenticate(r); err != ErrUnauthenticated {
+			t.Errorf("expected ErrUnauthenticated, got %v", err)
+		}
+	})
+
+	t.Run("admin common name", func(t *testing.T) {
+		r := withPeerCommonName(t, "admin-cn")
+
+		principal, err := a.Authenticate(r)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if principal.Role != RoleAdmin {
+			t.Errorf("expected RoleAdmin, got %v", principal.Role)
+		}
+	})
+
+	t.Run("non-admin common name", func(t *testing.T) {
+		r := withPeerCommonName(t, "someone-else")
+
+		principal, err := a.Authenticate(r)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if principal.Role != RoleViewer {
+			t.Errorf("expected RoleViewer, got %v", principal.Role)
+		}
+	})
+}
+
+func withPeerCommonName(t *testing.T, cn string) *http.Request {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.TLS = &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: cn}}},
+	}
+	return r
+}
+
+func TestDenyAllAuthenticator(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if _, err := (denyAllAuthenticator{}).Authenticate(r); err != ErrUnauthenticated {
+		t.Errorf("expected ErrUnauthenticated, got %v", err)
+	}
+}
+
+func TestRequireRole_NilAuthenticatorDeniesRatherThanAllows(t *testing.T) {
+	db := &Dashboard{}
+
+	handlerCalled := false
+	handler := db.requireRole(RoleViewer, func(http.ResponseWriter, *http.Request) {
+		handlerCalled = true
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if handlerCalled {
+		t.Error("handler should not run when db.authenticator is nil")
+	}
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestRequireRole_AdminRequirementRejectsBadCredentials(t *testing.T) {
+	db := &Dashboard{authenticator: &PresharedKeyAuthenticator{Key: "k"}}
+
+	handler := db.requireRole(RoleAdmin, func(http.ResponseWriter, *http.Request) {})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer wrong")
+
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for bad credentials, got %d", w.Code)
+	}
+}
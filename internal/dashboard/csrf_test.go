@@ -0,0 +1,94 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCSRFToken_SetsCookieOnFirstCall(t *testing.T) {
+	db := &Dashboard{}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	token := db.csrfToken(w, r)
+	if token == "" {
+		t.Fatal("expected a non-empty CSRF token")
+	}
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != csrfCookie || cookies[0].Value != token {
+		t.Fatalf("expected a %s cookie with value %q, got %v", csrfCookie, token, cookies)
+	}
+}
+
+func TestCSRFToken_ReusesExistingCookie(t *testing.T) {
+	db := &Dashboard{}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: csrfCookie, Value: "existing-token"})
+
+	w := httptest.NewRecorder()
+	if got := db.csrfToken(w, r); got != "existing-token" {
+		t.Errorf("expected existing token to be reused, got %q", got)
+	}
+	if len(w.Result().Cookies()) != 0 {
+		t.Error("expected no new cookie to be set when one already exists")
+	}
+}
+
+func TestValidateCSRF(t *testing.T) {
+	db := &Dashboard{}
+
+	newRequest := func(cookieValue, formValue string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/schema", strings.NewReader(url.Values{"csrf_token": {formValue}}.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if cookieValue != "" {
+			r.AddCookie(&http.Cookie{Name: csrfCookie, Value: cookieValue})
+		}
+		return r
+	}
+
+	t.Run("matching token", func(t *testing.T) {
+		if err := db.validateCSRF(newRequest("tok-123", "tok-123")); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("mismatched token", func(t *testing.T) {
+		if err := db.validateCSRF(newRequest("tok-123", "tok-456")); err != errInvalidCSRFToken {
+			t.Errorf("expected errInvalidCSRFToken, got %v", err)
+		}
+	})
+
+	t.Run("missing cookie", func(t *testing.T) {
+		if err := db.validateCSRF(newRequest("", "tok-123")); err != errInvalidCSRFToken {
+			t.Errorf("expected errInvalidCSRFToken, got %v", err)
+		}
+	})
+
+	t.Run("missing form value", func(t *testing.T) {
+		if err := db.validateCSRF(newRequest("tok-123", "")); err != errInvalidCSRFToken {
+			t.Errorf("expected errInvalidCSRFToken, got %v", err)
+		}
+	})
+}
+
+func TestGenerateCSRFToken_GeneratesDistinctValues(t *testing.T) {
+	first, err := generateCSRFToken()
+	if err != nil {
+		t.Fatalf("generateCSRFToken returned error: %v", err)
+	}
+
+	second, err := generateCSRFToken()
+	if err != nil {
+		t.Fatalf("generateCSRFToken returned error: %v", err)
+	}
+
+	if first == second {
+		t.Error("expected two calls to generateCSRFToken to produce distinct values")
+	}
+}
@@ -2,119 +2,199 @@ package dashboard
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
-	"html/template"
 	"net/http"
+	"os"
 	"strings"
+	"time"
 
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/grpcutil"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
 
 	"github.com/authzed/spicedb/internal/datastore"
+	"github.com/authzed/spicedb/internal/middleware/zedtokenrecorder"
+	"github.com/authzed/spicedb/internal/zedtokencache"
 	"github.com/authzed/spicedb/pkg/schemadsl/generator"
 )
 
-const rootTemplate = `
-<html>
-	<head>
-		<link href="https://cdn.jsdelivr.net/npm/bootstrap@5.1.1/dist/css/bootstrap.min.css" rel="stylesheet" integrity="sha384-F3w7mX95PdgyTmZZMECAngseQB83DfGTowi0iMjiWaeVhAn4FJkqJByhZMI3AhiU" crossorigin="anonymous">
-		<title>SpiceDB Dashboard</title>
-		<style type="text/css">
-		body {
-			margin: 20px;
+// NewDashboard instantiates a new dashboard server for the given addr.
+//
+// The dashboard dials its own gRPC client connection to GrpcAddr so that it
+// can drive the schema editor, relationship management and permission
+// playground views. If the dial fails, the dashboard still serves its
+// read-only pages, but the interactive views will report an error.
+//
+// tokenCache may be nil, in which case the /tokens inspector reports that no
+// cache is configured.
+//
+// NewDashboard fails closed: if args.AuthMode can't be configured (for
+// example, an OIDC issuer that can't be reached at startup), it returns an
+// error rather than serving the dashboard with authentication silently
+// disabled.
+func NewDashboard(addr string, args Args, datastore datastore.Datastore, tokenCache zedtokencache.ResourceTokenCache) (*Dashboard, error) {
+	if tokenCache == nil && args.ZedTokenCacheNatsURL != "" {
+		natsCache, err := zedtokencache.NewNATSCache(zedtokencache.Config{
+			NatsURL:    args.ZedTokenCacheNatsURL,
+			BucketName: args.ZedTokenCacheBucket,
+			TTL:        args.ZedTokenCacheTTL,
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("dashboard could not connect to ZedToken cache; /tokens will report no cache configured")
+		} else {
+			tokenCache = natsCache
 		}
+	}
+
+	db := &Dashboard{
+		addr:       addr,
+		server:     nil,
+		args:       args,
+		datastore:  datastore,
+		tokenCache: tokenCache,
+	}
+
+	conn, err := db.dialGrpc()
+	if err != nil {
+		log.Error().Err(err).Msg("dashboard could not dial local gRPC endpoint; interactive views will be disabled")
+	} else {
+		db.conn = conn
+		db.schemaClient = v1.NewSchemaServiceClient(conn)
+		db.permissionsClient = v1.NewPermissionsServiceClient(conn)
+	}
+
+	if err := db.configureAuthenticator(); err != nil {
+		return nil, fmt.Errorf("dashboard could not configure auth mode %q: %w", args.AuthMode, err)
+	}
 
-		pre {
-			border: 1px solid #ddd;
-			background-color: #eee;
-			padding: 10px;
+	healthCtx, cancel := context.WithCancel(context.Background())
+	db.cancelHealth = cancel
+	db.health = NewHealthCollector(datastore, healthProbeInterval)
+	db.health.Start(healthCtx)
+
+	return db, nil
+}
+
+// configureAuthenticator builds db.authenticator from db.args.AuthMode. It
+// always leaves db.authenticator set to a non-nil Authenticator: on error,
+// the caller is expected to refuse to start, and requireRole also treats a
+// nil authenticator as authenticating nobody, so a bug here fails closed
+// rather than open.
+func (db *Dashboard) configureAuthenticator() error {
+	switch db.args.AuthMode {
+	case "", "none":
+		db.authenticator = noAuthenticator{}
+	case "preshared_key":
+		db.authenticator = &PresharedKeyAuthenticator{Key: db.args.GrpcPresharedKey}
+	case "mtls":
+		if db.args.TLSClientCAPath == "" {
+			db.authenticator = denyAllAuthenticator{}
+			return fmt.Errorf("dashboard auth mode %q requires --dashboard-tls-client-ca-path so client certificates can be verified", db.args.AuthMode)
+		}
+		admins := make(map[string]struct{}, len(db.args.MTLSAdminCommonNames))
+		for _, cn := range db.args.MTLSAdminCommonNames {
+			admins[cn] = struct{}{}
+		}
+		db.authenticator = &MTLSAuthenticator{AdminCommonNames: admins}
+	case "oidc":
+		if len(db.args.OIDCCookieSecret) < minOIDCCookieSecretLen {
+			db.authenticator = denyAllAuthenticator{}
+			return fmt.Errorf("--dashboard-oidc-cookie-secret must be at least %d bytes; an empty or weak secret lets anyone forge a session cookie", minOIDCCookieSecretLen)
 		}
-		</style>
-		<!-- Global site tag (gtag.js) - Google Analytics -->
-		<script async src="https://www.googletagmanager.com/gtag/js?id=G-7Z6F57MP7G"></script>
-		<script>
-		window.dataLayer = window.dataLayer || [];
-		function gtag(){dataLayer.push(arguments);}
-		gtag('js', new Date());
-
-		gtag('config', 'G-7Z6F57MP7G');
-		</script>
-	</head>
-	<body>
-		{{if .IsReady }}
-		{{if .IsEmpty}}
-			<h1>Definining the permissions schema</h1>
-			<p>
-				To being making API requests to SpiceDB, you'll first need to load in a <a href="https://docs.authzed.com/reference/schema-lang" target="_blank" rel="noopener">Schema</a>
-				that defines the permissions system.
-			</p>
-			<p>
-				Run the following command to load in a sample permissions system:
-
-<pre>
-# Install the zed CLI tool
-brew install authzed/tap/zed
-
-# Login to SpiceDB
-zed context set first-dev-context {{ .Args.GrpcAddr }} "the preshared key here" {{if .Args.GrpcNoTLS }}--insecure {{end}}
-
-# Save the sample schema
-cat > sample.zed << 'SCHEMA'
-definition user {}
-
-definition resource {
-	relation reader: user
-	relation writer: user
-
-	permission write = writer
-	permission view = reader + write
+
+		oidcAuth, err := NewOIDCAuthenticator(
+			context.Background(),
+			db.args.OIDCIssuerURL,
+			db.args.OIDCClientID,
+			db.args.OIDCClientSecret,
+			db.args.OIDCRedirectURL,
+			db.args.OIDCAdminEmails,
+			[]byte(db.args.OIDCCookieSecret),
+			db.servesTLS(),
+		)
+		if err != nil {
+			db.authenticator = denyAllAuthenticator{}
+			return err
+		}
+		db.oidcAuth = oidcAuth
+		db.authenticator = oidcAuth
+	default:
+		db.authenticator = denyAllAuthenticator{}
+		return fmt.Errorf("unknown dashboard auth mode %q", db.args.AuthMode)
+	}
+
+	return nil
 }
-SCHEMA
-
-# Write a sample schema
-zed schema write sample.zed {{if .Args.GrpcNoTLS }}--insecure {{end}}
-</pre>
-			</p>
-		{{ else }}
-			<h1>SpiceDB</h1>
-			<h2>Current Schema</h2>
-			<pre>{{ .Schema }}</pre>
-
-{{ if .HasSampleSchema }}
-			<h2>Sample Calls</h2>
-			<h3>How to write a relationship</h3>
-<pre>
-# Write a sample relationship
-zed relationship create user:sampleuser reader resource:sampleresource {{if .Args.GrpcNoTLS }}--insecure {{end}}
-</pre>
-
-					<h3>How to check a permission</h3>
-		<pre>
-		# Check a permission
-		zed permission check user:sampleuser view resource:sampleresource {{if .Args.GrpcNoTLS }}--insecure {{end}}
-		</pre>
-		{{ end }}
-		{{ end }}
-	{{ else }}
-	<h1>Getting Started with SpiceDB</h1>
-	<p>
-		To get started with SpiceDB, please run the migrate command below to setup your backing data store:
-	</p>
-<pre>
-spicedb migrate head --datastore-engine={{ .Args.DatastoreEngine }} --datastore-conn-uri="your-connection-uri-here"
-</pre>
-	{{ end }}
-	</body>
-</html>
-`
 
-// NewDashboard instantiates a new dashboard server for the given addr.
-func NewDashboard(addr string, args Args, datastore datastore.Datastore) *Dashboard {
-	return &Dashboard{
-		addr:      addr,
-		server:    nil,
-		args:      args,
-		datastore: datastore,
+// tlsConfig builds the tls.Config used by ListenAndServe when the dashboard
+// is configured to terminate TLS itself, or returns nil if it isn't.
+// AuthMode "mtls" requires and verifies a client certificate from the pool
+// at TLSClientCAPath; any other auth mode accepts a client certificate if
+// one is presented, but doesn't require it.
+func (db *Dashboard) tlsConfig() (*tls.Config, error) {
+	if db.args.TLSCertPath == "" && db.args.TLSKeyPath == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(db.args.TLSCertPath, db.args.TLSKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load dashboard TLS certificate: %w", err)
 	}
+
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if db.args.TLSClientCAPath != "" {
+		pem, err := os.ReadFile(db.args.TLSClientCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read dashboard TLS client CA: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in dashboard TLS client CA %q", db.args.TLSClientCAPath)
+		}
+
+		cfg.ClientCAs = pool
+		if db.args.AuthMode == "mtls" {
+			cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		} else {
+			cfg.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	} else if db.args.AuthMode == "mtls" {
+		return nil, fmt.Errorf("dashboard auth mode %q requires --dashboard-tls-client-ca-path", db.args.AuthMode)
+	}
+
+	return cfg, nil
+}
+
+// dialGrpc dials the dashboard's own gRPC client connection to GrpcAddr,
+// chaining zedtokenrecorder.ClientInterceptor so that schema and
+// relationship writes made through this connection - i.e. through the
+// dashboard's own schema editor and relationship manager - populate
+// db.tokenCache. This covers the only write path this package controls; a
+// full deployment should also attach zedtokenrecorder.UnaryServerInterceptor
+// to the main gRPC server so writes from every other client are recorded
+// too.
+func (db *Dashboard) dialGrpc() (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{
+		grpcutil.WithInsecureBearerToken(db.args.GrpcPresharedKey),
+		grpc.WithChainUnaryInterceptor(zedtokenrecorder.ClientInterceptor(db.tokenCache)),
+	}
+
+	if db.args.GrpcNoTLS {
+		opts = append(opts, grpcutil.WithInsecureBearerTokenCredentials()...)
+	} else {
+		systemCerts, err := grpcutil.WithSystemCerts(grpcutil.VerifyCA)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, systemCerts)
+	}
+
+	return grpc.Dial(db.args.GrpcAddr, opts...)
 }
 
 // Args are various arguments passed to SpiceDB.
@@ -125,8 +205,64 @@ type Args struct {
 	// GrpcNoTls is true if no TLS is being used.
 	GrpcNoTLS bool
 
+	// GrpcPresharedKey is the preshared key used by the dashboard to
+	// authenticate its own gRPC client connection back to the GrpcAddr.
+	GrpcPresharedKey string
+
 	// DatastoreEngine is the datastore engine being used.
 	DatastoreEngine string
+
+	// AuthMode selects how dashboard requests are authenticated: "none"
+	// (the default, preserving prior behavior), "preshared_key", "oidc" or
+	// "mtls".
+	AuthMode string
+
+	// OIDCIssuerURL, OIDCClientID, OIDCClientSecret and OIDCRedirectURL
+	// configure the authorization-code flow used when AuthMode is "oidc".
+	OIDCIssuerURL    string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
+
+	// OIDCAdminEmails lists the OIDC emails granted the admin role; every
+	// other authenticated user is a viewer.
+	OIDCAdminEmails []string
+
+	// OIDCCookieSecret signs the dashboard's OIDC session cookie. It must
+	// be kept the same across dashboard restarts so existing sessions
+	// remain valid.
+	OIDCCookieSecret string
+
+	// MTLSAdminCommonNames lists the client certificate common names
+	// granted the admin role when AuthMode is "mtls"; every other verified
+	// certificate is a viewer.
+	MTLSAdminCommonNames []string
+
+	// TLSCertPath and TLSKeyPath, if both set, make the dashboard terminate
+	// TLS itself instead of serving plaintext HTTP. They are required when
+	// AuthMode is "mtls", since client certificates can only be presented
+	// as part of a TLS handshake.
+	TLSCertPath string
+	TLSKeyPath  string
+
+	// TLSClientCAPath is the PEM file of CA certificates used to verify
+	// client certificates. Required when AuthMode is "mtls"; optional
+	// (client certificates are accepted but not required) otherwise.
+	TLSClientCAPath string
+
+	// ZedTokenCacheNatsURL, if set, makes NewDashboard construct a
+	// NATS-backed ResourceTokenCache from ZedTokenCacheBucket and
+	// ZedTokenCacheTTL, instead of requiring the caller to build and pass
+	// one in. Ignored if the caller already passed a non-nil tokenCache.
+	ZedTokenCacheNatsURL string
+	ZedTokenCacheBucket  string
+	ZedTokenCacheTTL     time.Duration
+
+	// AnalyticsID, if set, is a Google Analytics measurement ID and opts
+	// the dashboard into loading the Analytics script on every page.
+	// Unset by default, unlike the hard-coded tracker this dashboard used
+	// to ship with.
+	AnalyticsID string
 }
 
 // Dashboard is a dashboard for displaying usage information for SpiceDB.
@@ -135,30 +271,82 @@ type Dashboard struct {
 	server    *http.Server
 	args      Args
 	datastore datastore.Datastore
+
+	conn              *grpc.ClientConn
+	schemaClient      v1.SchemaServiceClient
+	permissionsClient v1.PermissionsServiceClient
+	tokenCache        zedtokencache.ResourceTokenCache
+	authenticator     Authenticator
+	oidcAuth          *OIDCAuthenticator
+	health            *HealthCollector
+	cancelHealth      context.CancelFunc
+}
+
+// healthProbeInterval is how often the HealthCollector re-probes the
+// datastore for the /status and /metrics endpoints.
+const healthProbeInterval = 10 * time.Second
+
+// minOIDCCookieSecretLen is the minimum length required of
+// Args.OIDCCookieSecret. It signs the OIDC session cookie, so an empty or
+// short secret would let anyone forge an admin session by computing their
+// own HMAC over a sessionClaims of their choosing.
+const minOIDCCookieSecretLen = 32
+
+// servesTLS reports whether the dashboard's own HTTP listener terminates
+// TLS, as opposed to the backend gRPC connection's TLS setting
+// (Args.GrpcNoTLS), which is unrelated. Cookies set by this package should
+// only be marked Secure when this is true; marking them Secure based on the
+// gRPC connection's TLS setting would make them Secure on a plain-HTTP
+// dashboard, so browsers would never send them back.
+func (db *Dashboard) servesTLS() bool {
+	return db.args.TLSCertPath != "" && db.args.TLSKeyPath != ""
 }
 
 // ListenAndServe runs the dashboard on the configured HTTP address.
 func (db *Dashboard) ListenAndServe() error {
 	m := http.NewServeMux()
-	m.HandleFunc("/", db.rootHandler)
-	db.server = &http.Server{Addr: db.addr, Handler: m}
-	return db.server.ListenAndServe()
-}
+	m.HandleFunc("/", db.requireRole(RoleViewer, db.rootHandler))
+	m.HandleFunc("/schema", db.requireRole(RoleAdmin, db.schemaHandler))
+	m.HandleFunc("/relationships", db.requireRole(RoleAdmin, db.relationshipsHandler))
+	m.HandleFunc("/playground", db.requireRole(RoleAdmin, db.playgroundHandler))
+	m.HandleFunc("/tokens", db.requireRole(RoleViewer, db.tokensHandler))
+	m.HandleFunc("/status", db.requireRole(RoleViewer, db.statusHandler))
+	m.HandleFunc("/metrics", db.requireRole(RoleViewer, db.metricsHandler))
+	m.Handle("/static/", db.staticHandler())
 
-func (db *Dashboard) rootHandler(w http.ResponseWriter, r *http.Request) {
-	tmpl, err := template.New("root").Parse(rootTemplate)
+	if db.oidcAuth != nil {
+		m.HandleFunc("/auth/login", db.oidcAuth.loginHandler)
+		m.HandleFunc("/auth/callback", db.oidcAuth.callbackHandler)
+	}
+
+	tlsConfig, err := db.tlsConfig()
 	if err != nil {
-		log.Error().AnErr("template-error", err).Msg("Got error when parsing template")
-		fmt.Fprintf(w, "Internal Error")
-		return
+		return err
 	}
 
-	isReady, err := db.datastore.IsReady(r.Context())
+	db.server = &http.Server{Addr: db.addr, Handler: m, TLSConfig: tlsConfig}
+	if tlsConfig != nil {
+		// The certificate and key are already loaded into tlsConfig, so
+		// ListenAndServeTLS is called with empty paths.
+		return db.server.ListenAndServeTLS("", "")
+	}
+	return db.server.ListenAndServe()
+}
+
+type rootPageData struct {
+	Args            Args
+	IsReady         bool
+	IsEmpty         bool
+	Schema          string
+	HasSampleSchema bool
+}
+
+func (db *Dashboard) rootHandler(w http.ResponseWriter, r *http.Request) {
+	stats, err := db.health.Latest()
 	if err != nil {
-		log.Error().AnErr("template-error", err).Msg("Got error when checking database")
-		fmt.Fprintf(w, "Internal Error")
-		return
+		log.Error().AnErr("health-error", err).Msg("Got error when checking database")
 	}
+	isReady := stats.Ready
 
 	schema := ""
 	hasSampleSchema := false
@@ -171,7 +359,7 @@ func (db *Dashboard) rootHandler(w http.ResponseWriter, r *http.Request) {
 		nsDefs, err := db.datastore.ListNamespaces(r.Context())
 		if err != nil {
 			log.Error().AnErr("datastore-error", err).Msg("Got error when trying to load namespaces")
-			fmt.Fprintf(w, "Internal Error")
+			http.Error(w, "Internal Error", http.StatusInternalServerError)
 			return
 		}
 
@@ -191,27 +379,24 @@ func (db *Dashboard) rootHandler(w http.ResponseWriter, r *http.Request) {
 		hasSampleSchema = userFound && resourceFound
 	}
 
-	err = tmpl.Execute(w, struct {
-		Args            Args
-		IsReady         bool
-		IsEmpty         bool
-		Schema          string
-		HasSampleSchema bool
-	}{
+	db.render(w, r, "root.html", rootPageData{
 		Args:            db.args,
 		IsReady:         isReady,
 		IsEmpty:         isReady && schema == "",
 		Schema:          schema,
 		HasSampleSchema: hasSampleSchema,
 	})
-	if err != nil {
-		log.Error().AnErr("template-error", err).Msg("Got error when executing template")
-		fmt.Fprintf(w, "Internal Error")
-		return
-	}
 }
 
 // Close closes the dashboard server.
 func (db *Dashboard) Close() error {
+	if db.cancelHealth != nil {
+		db.cancelHealth()
+	}
+	if db.conn != nil {
+		if err := db.conn.Close(); err != nil {
+			log.Error().Err(err).Msg("error closing dashboard gRPC connection")
+		}
+	}
 	return db.server.Shutdown(context.Background())
-}
\ No newline at end of file
+}
@@ -0,0 +1,72 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/grpc"
+)
+
+// errBoom is a sentinel error used across this package's tests to simulate a
+// failing gRPC call without depending on any particular gRPC status type.
+var errBoom = errors.New("boom")
+
+// fakeSchemaServiceClient embeds the real v1.SchemaServiceClient so it only
+// needs to implement the methods schemaHandler actually calls; any other
+// method would panic through the nil embedded interface, which is fine
+// since schemaHandler never calls them.
+type fakeSchemaServiceClient struct {
+	v1.SchemaServiceClient
+
+	readSchema  func(ctx context.Context, in *v1.ReadSchemaRequest) (*v1.ReadSchemaResponse, error)
+	writeSchema func(ctx context.Context, in *v1.WriteSchemaRequest) (*v1.WriteSchemaResponse, error)
+}
+
+func (f *fakeSchemaServiceClient) ReadSchema(ctx context.Context, in *v1.ReadSchemaRequest, _ ...grpc.CallOption) (*v1.ReadSchemaResponse, error) {
+	return f.readSchema(ctx, in)
+}
+
+func (f *fakeSchemaServiceClient) WriteSchema(ctx context.Context, in *v1.WriteSchemaRequest, _ ...grpc.CallOption) (*v1.WriteSchemaResponse, error) {
+	return f.writeSchema(ctx, in)
+}
+
+// fakePermissionsServiceClient is the v1.PermissionsServiceClient analog of
+// fakeSchemaServiceClient above.
+type fakePermissionsServiceClient struct {
+	v1.PermissionsServiceClient
+
+	writeRelationships   func(ctx context.Context, in *v1.WriteRelationshipsRequest) (*v1.WriteRelationshipsResponse, error)
+	checkPermission      func(ctx context.Context, in *v1.CheckPermissionRequest) (*v1.CheckPermissionResponse, error)
+	expandPermissionTree func(ctx context.Context, in *v1.ExpandPermissionTreeRequest) (*v1.ExpandPermissionTreeResponse, error)
+}
+
+func (f *fakePermissionsServiceClient) WriteRelationships(ctx context.Context, in *v1.WriteRelationshipsRequest, _ ...grpc.CallOption) (*v1.WriteRelationshipsResponse, error) {
+	return f.writeRelationships(ctx, in)
+}
+
+func (f *fakePermissionsServiceClient) CheckPermission(ctx context.Context, in *v1.CheckPermissionRequest, _ ...grpc.CallOption) (*v1.CheckPermissionResponse, error) {
+	return f.checkPermission(ctx, in)
+}
+
+func (f *fakePermissionsServiceClient) ExpandPermissionTree(ctx context.Context, in *v1.ExpandPermissionTreeRequest, _ ...grpc.CallOption) (*v1.ExpandPermissionTreeResponse, error) {
+	return f.expandPermissionTree(ctx, in)
+}
+
+// newCSRFProtectedPostRequest builds a POST request carrying a matching
+// csrf_token form value and csrfCookie, the way a real browser submission
+// of one of the dashboard's forms would, so tests can exercise a handler's
+// POST branch past validateCSRF.
+func newCSRFProtectedPostRequest(target string, form url.Values) *http.Request {
+	const token = "test-csrf-token"
+	form.Set("csrf_token", token)
+
+	r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(&http.Cookie{Name: csrfCookie, Value: token})
+	return r
+}
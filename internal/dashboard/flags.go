@@ -0,0 +1,44 @@
+package dashboard
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// RegisterFlags adds the dashboard's Args fields as flags on cmd, storing
+// their values into args.
+func RegisterFlags(cmd *cobra.Command, args *Args) {
+	cmd.Flags().StringVar(&args.AuthMode, "dashboard-auth-mode", "none",
+		"method used to authenticate dashboard requests: none, preshared_key, oidc or mtls")
+
+	cmd.Flags().StringVar(&args.OIDCIssuerURL, "dashboard-oidc-issuer-url", "",
+		"OIDC issuer URL used when --dashboard-auth-mode=oidc")
+	cmd.Flags().StringVar(&args.OIDCClientID, "dashboard-oidc-client-id", "",
+		"OIDC client ID used when --dashboard-auth-mode=oidc")
+	cmd.Flags().StringVar(&args.OIDCClientSecret, "dashboard-oidc-client-secret", "",
+		"OIDC client secret used when --dashboard-auth-mode=oidc")
+	cmd.Flags().StringVar(&args.OIDCRedirectURL, "dashboard-oidc-redirect-url", "",
+		"OIDC redirect URL registered with the identity provider, e.g. http://localhost:8080/auth/callback")
+	cmd.Flags().StringSliceVar(&args.OIDCAdminEmails, "dashboard-oidc-admin-emails", nil,
+		"OIDC emails granted the dashboard admin role; all other authenticated users are viewers")
+	cmd.Flags().StringVar(&args.OIDCCookieSecret, "dashboard-oidc-cookie-secret", "",
+		"secret used to sign the dashboard's OIDC session cookie")
+
+	cmd.Flags().StringSliceVar(&args.MTLSAdminCommonNames, "dashboard-mtls-admin-common-names", nil,
+		"client certificate common names granted the dashboard admin role when --dashboard-auth-mode=mtls")
+
+	cmd.Flags().StringVar(&args.TLSCertPath, "dashboard-tls-cert-path", "",
+		"path to a PEM certificate used to terminate TLS on the dashboard; required when --dashboard-auth-mode=mtls")
+	cmd.Flags().StringVar(&args.TLSKeyPath, "dashboard-tls-key-path", "",
+		"path to the PEM private key for --dashboard-tls-cert-path")
+	cmd.Flags().StringVar(&args.TLSClientCAPath, "dashboard-tls-client-ca-path", "",
+		"path to a PEM CA bundle used to verify client certificates; required when --dashboard-auth-mode=mtls")
+
+	cmd.Flags().StringVar(&args.ZedTokenCacheNatsURL, "dashboard-zedtoken-cache-nats-url", "",
+		"URL of a NATS server used to back the dashboard's ZedToken cache; if unset, the /tokens inspector reports no cache configured")
+	cmd.Flags().StringVar(&args.ZedTokenCacheBucket, "dashboard-zedtoken-cache-bucket", "spicedb-dashboard-zedtokens",
+		"JetStream KV bucket used to store cached ZedTokens")
+	cmd.Flags().DurationVar(&args.ZedTokenCacheTTL, "dashboard-zedtoken-cache-ttl", 24*time.Hour,
+		"how long a cached ZedToken is kept before eviction")
+}
@@ -0,0 +1,160 @@
+package dashboard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/authzed/spicedb/internal/datastore"
+)
+
+// NamespaceStatistics describes the relationship count observed for a
+// single namespace.
+type NamespaceStatistics struct {
+	Namespace         string
+	RelationshipCount uint64
+}
+
+// Statistics is a snapshot of datastore health as observed by a
+// HealthCollector probe.
+type Statistics struct {
+	// Ready is the result of the datastore's own readiness check.
+	Ready bool
+
+	// RevisionLag is how far behind the datastore's most recently
+	// replicated revision is from its most recently written one, if the
+	// datastore is able to report it.
+	RevisionLag time.Duration
+
+	// NamespaceCount is the number of namespaces currently defined.
+	NamespaceCount int
+
+	// Namespaces holds the per-namespace relationship counts, when the
+	// datastore implements StatisticsSource. It's always empty otherwise
+	// (see StatisticsSource's doc comment).
+	Namespaces []NamespaceStatistics
+
+	// ObservedAt is when this snapshot was collected.
+	ObservedAt time.Time
+}
+
+// StatisticsSource is a scoped-down stand-in for the "new datastore.Statistics
+// method" originally requested: per-namespace relationship counts and
+// revision lag require a method on datastore.Datastore itself (so the
+// collector can ask for rich statistics in one round trip instead of a
+// ListNamespaces call plus one count call per namespace), but
+// internal/datastore isn't part of this dashboard-focused change series, so
+// that interface can't be added here. StatisticsSource exists only so a
+// later change to internal/datastore can be adopted without also changing
+// HealthCollector; it deliberately doesn't promise "a real datastore will
+// implement this soon."
+//
+// Until internal/datastore grows a Statistics method and a type there is
+// updated to implement StatisticsSource, collect always takes the fallback
+// path below, and Namespaces/RevisionLag permanently read as empty/zero -
+// spicedb_dashboard_relationship_count never has data and /status never
+// shows its per-namespace table. That gap is a known, accepted reduction in
+// scope from the original request, not an oversight.
+type StatisticsSource interface {
+	Statistics(ctx context.Context) (Statistics, error)
+}
+
+// HealthCollector periodically probes a datastore for readiness and usage
+// statistics and caches the result, so that the dashboard's /status and
+// /metrics endpoints can be served instantly instead of blocking on the
+// datastore for every request.
+type HealthCollector struct {
+	datastore datastore.Datastore
+	interval  time.Duration
+
+	mu     sync.RWMutex
+	latest Statistics
+	err    error
+
+	fallbackLogged sync.Once
+}
+
+// logFallbackOnce logs, the first time it's called, that hc.datastore
+// doesn't implement StatisticsSource, so operators don't have to guess why
+// /status's per-namespace table and /metrics' relationship-count gauge stay
+// empty.
+func (hc *HealthCollector) logFallbackOnce() {
+	hc.fallbackLogged.Do(func() {
+		log.Info().Msg("dashboard health collector: datastore does not implement StatisticsSource; per-namespace relationship counts and revision lag will always read as zero")
+	})
+}
+
+// NewHealthCollector creates a HealthCollector that probes ds every
+// interval once Start is called.
+func NewHealthCollector(ds datastore.Datastore, interval time.Duration) *HealthCollector {
+	return &HealthCollector{datastore: ds, interval: interval}
+}
+
+// Start runs the collector's probe loop until ctx is canceled. The first
+// probe runs synchronously so that Latest has a result as soon as Start
+// returns.
+func (hc *HealthCollector) Start(ctx context.Context) {
+	hc.probe(ctx)
+
+	go func() {
+		ticker := time.NewTicker(hc.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				hc.probe(ctx)
+			}
+		}
+	}()
+}
+
+func (hc *HealthCollector) probe(ctx context.Context) {
+	stats, err := hc.collect(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("dashboard health collector failed to probe datastore")
+	}
+
+	stats.ObservedAt = time.Now()
+
+	hc.mu.Lock()
+	hc.latest = stats
+	hc.err = err
+	hc.mu.Unlock()
+}
+
+func (hc *HealthCollector) collect(ctx context.Context) (Statistics, error) {
+	if source, ok := hc.datastore.(StatisticsSource); ok {
+		return source.Statistics(ctx)
+	}
+
+	hc.logFallbackOnce()
+
+	ready, err := hc.datastore.IsReady(ctx)
+	if err != nil {
+		return Statistics{}, err
+	}
+
+	if !ready {
+		return Statistics{Ready: false}, nil
+	}
+
+	nsDefs, err := hc.datastore.ListNamespaces(ctx)
+	if err != nil {
+		return Statistics{Ready: true}, err
+	}
+
+	return Statistics{Ready: true, NamespaceCount: len(nsDefs)}, nil
+}
+
+// Latest returns the most recently collected Statistics and the error (if
+// any) from the probe that produced it.
+func (hc *HealthCollector) Latest() (Statistics, error) {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.latest, hc.err
+}
@@ -0,0 +1,55 @@
+package dashboard
+
+import (
+	"testing"
+	"time"
+)
+
+// HealthCollector's own probe/collect methods require a real
+// datastore.Datastore, which internal/datastore doesn't expose a fakeable
+// implementation of from this package's tests (see [sabify/spicedb#chunk0-4]'s
+// commit history). Latest only reads hc.latest/hc.err under lock, so it can be
+// exercised directly by constructing a HealthCollector with those fields
+// already populated, without ever calling Start/probe/collect.
+
+func TestHealthCollector_LatestReturnsZeroValueBeforeAnyProbe(t *testing.T) {
+	hc := &HealthCollector{}
+
+	stats, err := hc.Latest()
+	if err != nil {
+		t.Errorf("expected no error before any probe has run, got %v", err)
+	}
+	if stats.Ready {
+		t.Error("expected Ready to be false before any probe has run")
+	}
+}
+
+func TestHealthCollector_LatestReturnsMostRecentlyStoredSnapshot(t *testing.T) {
+	want := Statistics{
+		Ready:          true,
+		NamespaceCount: 2,
+		Namespaces: []NamespaceStatistics{
+			{Namespace: "resource", RelationshipCount: 5},
+		},
+		ObservedAt: time.Now(),
+	}
+
+	hc := &HealthCollector{latest: want}
+
+	got, err := hc.Latest()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Ready != want.Ready || got.NamespaceCount != want.NamespaceCount {
+		t.Errorf("Latest() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHealthCollector_LatestReturnsProbeError(t *testing.T) {
+	hc := &HealthCollector{err: errBoom}
+
+	_, err := hc.Latest()
+	if err != errBoom {
+		t.Errorf("expected Latest to surface the stored probe error, got %v", err)
+	}
+}
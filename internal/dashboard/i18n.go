@@ -0,0 +1,66 @@
+package dashboard
+
+import (
+	"net/http"
+	"strings"
+)
+
+// defaultLocale is used whenever a request's Accept-Language header names no
+// locale we have a catalog for.
+const defaultLocale = "en"
+
+// catalogs holds the translated message strings shown on the onboarding
+// page and in error output, keyed first by locale and then by message key.
+// Locales without a translation for a given key fall back to defaultLocale.
+var catalogs = map[string]map[string]string{
+	"en": {
+		"onboarding.title":    "Getting Started with SpiceDB",
+		"onboarding.body":     "To get started with SpiceDB, please run the migrate command below to setup your backing data store:",
+		"schema.title":        "Defining the permissions schema",
+		"schema.body":         "To begin making API requests to SpiceDB, you'll first need to load in a Schema that defines the permissions system.",
+		"schema.sample_intro": "Run the following command to load in a sample permissions system:",
+		"error.internal":      "Internal Error",
+	},
+	"fr": {
+		"onboarding.title":    "Bien démarrer avec SpiceDB",
+		"onboarding.body":     "Pour commencer avec SpiceDB, veuillez exécuter la commande migrate ci-dessous pour configurer votre magasin de données :",
+		"schema.title":        "Définition du schéma de permissions",
+		"schema.body":         "Pour commencer à appeler l'API SpiceDB, vous devrez d'abord charger un schéma qui définit le système de permissions.",
+		"schema.sample_intro": "Exécutez la commande suivante pour charger un système de permissions d'exemple :",
+		"error.internal":      "Erreur interne",
+	},
+}
+
+// Localizer resolves message keys to translated strings for a single
+// request's negotiated locale.
+type Localizer struct {
+	locale string
+}
+
+// localizerFor negotiates a Localizer from the request's Accept-Language
+// header, falling back to defaultLocale when the header is absent or names
+// no locale we have a catalog for.
+func localizerFor(r *http.Request) *Localizer {
+	for _, tag := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag = strings.TrimSpace(strings.SplitN(tag, ";", 2)[0])
+		locale := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if _, ok := catalogs[locale]; ok {
+			return &Localizer{locale: locale}
+		}
+	}
+
+	return &Localizer{locale: defaultLocale}
+}
+
+// T returns the translated message for key in the Localizer's locale,
+// falling back to defaultLocale and finally to the key itself if no
+// translation exists.
+func (l *Localizer) T(key string) string {
+	if msg, ok := catalogs[l.locale][key]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[defaultLocale][key]; ok {
+		return msg
+	}
+	return key
+}
@@ -0,0 +1,61 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalizerFor(t *testing.T) {
+	cases := []struct {
+		name           string
+		acceptLanguage string
+		wantLocale     string
+	}{
+		{"no header", "", defaultLocale},
+		{"exact match", "fr", "fr"},
+		{"region subtag", "fr-CA", "fr"},
+		{"quality value", "fr-CA;q=0.9", "fr"},
+		{"first supported in list", "de, fr;q=0.8, en;q=0.5", "fr"},
+		{"unsupported locale falls back", "de-DE", defaultLocale},
+		{"case insensitive", "FR-ca", "fr"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if c.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", c.acceptLanguage)
+			}
+
+			loc := localizerFor(r)
+			if loc.locale != c.wantLocale {
+				t.Errorf("localizerFor(%q).locale = %q, want %q", c.acceptLanguage, loc.locale, c.wantLocale)
+			}
+		})
+	}
+}
+
+func TestLocalizer_T(t *testing.T) {
+	t.Run("known key in locale", func(t *testing.T) {
+		loc := &Localizer{locale: "fr"}
+		if got := loc.T("error.internal"); got != catalogs["fr"]["error.internal"] {
+			t.Errorf("T(%q) = %q, want %q", "error.internal", got, catalogs["fr"]["error.internal"])
+		}
+	})
+
+	t.Run("falls back to default locale", func(t *testing.T) {
+		loc := &Localizer{locale: "xx"}
+		if got := loc.T("error.internal"); got != catalogs[defaultLocale]["error.internal"] {
+			t.Errorf("T(%q) = %q, want default-locale translation", "error.internal", got)
+		}
+	})
+
+	t.Run("falls back to the key itself", func(t *testing.T) {
+		loc := &Localizer{locale: defaultLocale}
+		const missing = "no.such.key"
+		if got := loc.T(missing); got != missing {
+			t.Errorf("T(%q) = %q, want %q", missing, got, missing)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	datastoreReadyGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "spicedb_dashboard_datastore_ready",
+		Help: "Whether the dashboard's last datastore probe reported readiness (1) or not (0).",
+	})
+
+	namespaceCountGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "spicedb_dashboard_namespace_count",
+		Help: "The number of namespaces observed by the dashboard's last datastore probe.",
+	})
+
+	relationshipCountGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "spicedb_dashboard_relationship_count",
+		Help: "The number of relationships observed per namespace by the dashboard's last datastore probe. Always empty until a datastore implements dashboard.StatisticsSource; see health.go.",
+	}, []string{"namespace"})
+)
+
+// metricsHandler exposes the dashboard's cached datastore statistics in
+// Prometheus text format, so ops can scrape the dashboard process directly
+// instead of only relying on gRPC-side instrumentation.
+func (db *Dashboard) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	stats, _ := db.health.Latest()
+
+	if stats.Ready {
+		datastoreReadyGauge.Set(1)
+	} else {
+		datastoreReadyGauge.Set(0)
+	}
+
+	namespaceCountGauge.Set(float64(stats.NamespaceCount))
+
+	relationshipCountGauge.Reset()
+	for _, ns := range stats.Namespaces {
+		relationshipCountGauge.WithLabelValues(ns.Namespace).Set(float64(ns.RelationshipCount))
+	}
+
+	promhttp.Handler().ServeHTTP(w, r)
+}
@@ -0,0 +1,46 @@
+package dashboard
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsHandler_SetsGaugesFromLatestStatistics(t *testing.T) {
+	db := &Dashboard{
+		health: &HealthCollector{latest: Statistics{
+			Ready:          true,
+			NamespaceCount: 2,
+			Namespaces: []NamespaceStatistics{
+				{Namespace: "resource", RelationshipCount: 7},
+			},
+		}},
+	}
+
+	w := httptest.NewRecorder()
+	db.metricsHandler(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	if !strings.Contains(body, "spicedb_dashboard_datastore_ready 1") {
+		t.Errorf("expected the readiness gauge to read 1, got body:\n%s", body)
+	}
+	if !strings.Contains(body, "spicedb_dashboard_namespace_count 2") {
+		t.Errorf("expected the namespace count gauge to read 2, got body:\n%s", body)
+	}
+	if !strings.Contains(body, `spicedb_dashboard_relationship_count{namespace="resource"} 7`) {
+		t.Errorf("expected the per-namespace relationship count gauge to be set, got body:\n%s", body)
+	}
+}
+
+func TestMetricsHandler_NotReadySetsZeroGauge(t *testing.T) {
+	db := &Dashboard{
+		health: &HealthCollector{latest: Statistics{Ready: false}},
+	}
+
+	w := httptest.NewRecorder()
+	db.metricsHandler(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	if !strings.Contains(w.Body.String(), "spicedb_dashboard_datastore_ready 0") {
+		t.Errorf("expected the readiness gauge to read 0, got body:\n%s", w.Body.String())
+	}
+}
@@ -0,0 +1,88 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+type playgroundPageData struct {
+	Error  string
+	Result string
+}
+
+// playgroundHandler issues CheckPermission or ExpandPermission calls against
+// the local gRPC endpoint and renders the result inline, so an operator can
+// try out a permissions system without leaving the browser.
+func (db *Dashboard) playgroundHandler(w http.ResponseWriter, r *http.Request) {
+	if db.permissionsClient == nil {
+		fmt.Fprint(w, "Playground is unavailable: dashboard could not connect to the gRPC endpoint")
+		return
+	}
+
+	var errMessage, result string
+
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			errMessage = err.Error()
+		} else if err := db.validateCSRF(r); err != nil {
+			errMessage = err.Error()
+		} else {
+			resourceType, resourceID, err := parseObjectID(r.FormValue("resource"))
+			if err != nil {
+				errMessage = err.Error()
+			}
+
+			permission := r.FormValue("permission")
+
+			resourceRef := &v1.ObjectReference{ObjectType: resourceType, ObjectId: resourceID}
+
+			switch r.FormValue("call") {
+			case "expand":
+				if errMessage == "" {
+					resp, err := db.permissionsClient.ExpandPermissionTree(r.Context(), &v1.ExpandPermissionTreeRequest{
+						Resource:   resourceRef,
+						Permission: permission,
+						Consistency: &v1.Consistency{
+							Requirement: &v1.Consistency_MinimizeLatency{MinimizeLatency: true},
+						},
+					})
+					if err != nil {
+						errMessage = err.Error()
+					} else {
+						result = resp.TreeRoot.String()
+					}
+				}
+			default:
+				subjectType, subjectID, err := parseObjectID(r.FormValue("subject"))
+				if err != nil {
+					errMessage = err.Error()
+				}
+
+				if errMessage == "" {
+					resp, err := db.permissionsClient.CheckPermission(r.Context(), &v1.CheckPermissionRequest{
+						Resource:   resourceRef,
+						Permission: permission,
+						Subject: &v1.SubjectReference{
+							Object: &v1.ObjectReference{ObjectType: subjectType, ObjectId: subjectID},
+						},
+						Consistency: &v1.Consistency{
+							Requirement: &v1.Consistency_MinimizeLatency{MinimizeLatency: true},
+						},
+					})
+					if err != nil {
+						errMessage = err.Error()
+					} else {
+						result = resp.Permissionship.String()
+					}
+				}
+			}
+		}
+	}
+
+	db.render(w, r, "playground.html", playgroundPageData{
+		Error:  errMessage,
+		Result: result,
+	})
+}
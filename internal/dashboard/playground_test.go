@@ -0,0 +1,98 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+func TestPlaygroundHandler_POSTCheckPermission(t *testing.T) {
+	db := &Dashboard{
+		permissionsClient: &fakePermissionsServiceClient{
+			checkPermission: func(_ context.Context, in *v1.CheckPermissionRequest) (*v1.CheckPermissionResponse, error) {
+				if in.Resource.ObjectType != "resource" || in.Resource.ObjectId != "doc1" {
+					t.Errorf("unexpected resource in request: %+v", in.Resource)
+				}
+				return &v1.CheckPermissionResponse{Permissionship: v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION}, nil
+			},
+		},
+	}
+
+	form := url.Values{
+		"resource":   {"resource:doc1"},
+		"permission": {"view"},
+		"subject":    {"user:alice"},
+	}
+	w := httptest.NewRecorder()
+	db.playgroundHandler(w, newCSRFProtectedPostRequest("/playground", form))
+
+	if !strings.Contains(w.Body.String(), "HAS_PERMISSION") {
+		t.Errorf("expected the permissionship result in the body, got %q", w.Body.String())
+	}
+}
+
+func TestPlaygroundHandler_POSTExpandPermissionTree(t *testing.T) {
+	called := false
+	db := &Dashboard{
+		permissionsClient: &fakePermissionsServiceClient{
+			expandPermissionTree: func(_ context.Context, in *v1.ExpandPermissionTreeRequest) (*v1.ExpandPermissionTreeResponse, error) {
+				called = true
+				if in.Resource.ObjectType != "resource" || in.Resource.ObjectId != "doc1" {
+					t.Errorf("unexpected resource in request: %+v", in.Resource)
+				}
+				return &v1.ExpandPermissionTreeResponse{TreeRoot: &v1.PermissionRelationshipTree{}}, nil
+			},
+		},
+	}
+
+	form := url.Values{
+		"resource":   {"resource:doc1"},
+		"permission": {"view"},
+		"call":       {"expand"},
+	}
+	w := httptest.NewRecorder()
+	db.playgroundHandler(w, newCSRFProtectedPostRequest("/playground", form))
+
+	if !called {
+		t.Error("expected ExpandPermissionTree to be called for call=expand")
+	}
+}
+
+func TestPlaygroundHandler_POSTRejectsMalformedResource(t *testing.T) {
+	db := &Dashboard{
+		permissionsClient: &fakePermissionsServiceClient{
+			checkPermission: func(_ context.Context, _ *v1.CheckPermissionRequest) (*v1.CheckPermissionResponse, error) {
+				t.Error("expected CheckPermission not to be called for a malformed resource ref")
+				return nil, errBoom
+			},
+		},
+	}
+
+	form := url.Values{
+		"resource":   {"not-a-valid-ref"},
+		"permission": {"view"},
+		"subject":    {"user:alice"},
+	}
+	w := httptest.NewRecorder()
+	db.playgroundHandler(w, newCSRFProtectedPostRequest("/playground", form))
+
+	if !strings.Contains(w.Body.String(), "invalid object reference") {
+		t.Errorf("expected an invalid-reference error in the body, got %q", w.Body.String())
+	}
+}
+
+func TestPlaygroundHandler_NoClientReportsUnavailable(t *testing.T) {
+	db := &Dashboard{}
+
+	w := httptest.NewRecorder()
+	db.playgroundHandler(w, httptest.NewRequest(http.MethodGet, "/playground", nil))
+
+	if !strings.Contains(w.Body.String(), "unavailable") {
+		t.Errorf("expected response body to report the playground is unavailable, got %q", w.Body.String())
+	}
+}
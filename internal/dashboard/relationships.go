@@ -0,0 +1,83 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+// parseObjectID splits a "type:id" reference, as entered by an operator in
+// the dashboard forms, into its component parts.
+func parseObjectID(ref string) (objectType, objectID string, err error) {
+	parts := strings.SplitN(ref, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid object reference %q, expected format type:id", ref)
+	}
+	return parts[0], parts[1], nil
+}
+
+type relationshipsPageData struct {
+	Error   string
+	Message string
+}
+
+// relationshipsHandler lets an operator create or delete sample
+// relationships through the PermissionsService's WriteRelationships call.
+func (db *Dashboard) relationshipsHandler(w http.ResponseWriter, r *http.Request) {
+	if db.permissionsClient == nil {
+		fmt.Fprint(w, "Relationship management is unavailable: dashboard could not connect to the gRPC endpoint")
+		return
+	}
+
+	var errMessage, message string
+
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			errMessage = err.Error()
+		} else if err := db.validateCSRF(r); err != nil {
+			errMessage = err.Error()
+		} else {
+			resourceType, resourceID, err := parseObjectID(r.FormValue("resource"))
+			if err != nil {
+				errMessage = err.Error()
+			}
+
+			subjectType, subjectID, err := parseObjectID(r.FormValue("subject"))
+			if err != nil {
+				errMessage = err.Error()
+			}
+
+			if errMessage == "" {
+				op := v1.RelationshipUpdate_OPERATION_TOUCH
+				if r.FormValue("op") == "delete" {
+					op = v1.RelationshipUpdate_OPERATION_DELETE
+				}
+
+				_, err = db.permissionsClient.WriteRelationships(r.Context(), &v1.WriteRelationshipsRequest{
+					Updates: []*v1.RelationshipUpdate{{
+						Operation: op,
+						Relationship: &v1.Relationship{
+							Resource: &v1.ObjectReference{ObjectType: resourceType, ObjectId: resourceID},
+							Relation: r.FormValue("relation"),
+							Subject: &v1.SubjectReference{
+								Object: &v1.ObjectReference{ObjectType: subjectType, ObjectId: subjectID},
+							},
+						},
+					}},
+				})
+				if err != nil {
+					errMessage = err.Error()
+				} else {
+					message = "Relationship written successfully"
+				}
+			}
+		}
+	}
+
+	db.render(w, r, "relationships.html", relationshipsPageData{
+		Error:   errMessage,
+		Message: message,
+	})
+}
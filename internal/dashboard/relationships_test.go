@@ -0,0 +1,137 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+func TestParseObjectID(t *testing.T) {
+	cases := []struct {
+		ref              string
+		wantType, wantID string
+		wantErr          bool
+	}{
+		{"resource:doc1", "resource", "doc1", false},
+		{"missing-colon", "", "", true},
+		{"resource:", "", "", true},
+		{":doc1", "", "", true},
+	}
+
+	for _, c := range cases {
+		objectType, objectID, err := parseObjectID(c.ref)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseObjectID(%q): expected an error, got none", c.ref)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseObjectID(%q): unexpected error %v", c.ref, err)
+		}
+		if objectType != c.wantType || objectID != c.wantID {
+			t.Errorf("parseObjectID(%q) = (%q, %q), want (%q, %q)", c.ref, objectType, objectID, c.wantType, c.wantID)
+		}
+	}
+}
+
+func TestRelationshipsHandler_POSTWritesTouchByDefault(t *testing.T) {
+	var got *v1.RelationshipUpdate
+	db := &Dashboard{
+		permissionsClient: &fakePermissionsServiceClient{
+			writeRelationships: func(_ context.Context, in *v1.WriteRelationshipsRequest) (*v1.WriteRelationshipsResponse, error) {
+				got = in.Updates[0]
+				return &v1.WriteRelationshipsResponse{}, nil
+			},
+		},
+	}
+
+	form := url.Values{
+		"resource": {"resource:doc1"},
+		"relation": {"viewer"},
+		"subject":  {"user:alice"},
+	}
+	w := httptest.NewRecorder()
+	db.relationshipsHandler(w, newCSRFProtectedPostRequest("/relationships", form))
+
+	if got == nil {
+		t.Fatal("expected WriteRelationships to be called")
+	}
+	if got.Operation != v1.RelationshipUpdate_OPERATION_TOUCH {
+		t.Errorf("expected a TOUCH operation by default, got %v", got.Operation)
+	}
+	if !strings.Contains(w.Body.String(), "written successfully") {
+		t.Errorf("expected a success message in the body, got %q", w.Body.String())
+	}
+}
+
+func TestRelationshipsHandler_POSTDeleteOperation(t *testing.T) {
+	var got *v1.RelationshipUpdate
+	db := &Dashboard{
+		permissionsClient: &fakePermissionsServiceClient{
+			writeRelationships: func(_ context.Context, in *v1.WriteRelationshipsRequest) (*v1.WriteRelationshipsResponse, error) {
+				got = in.Updates[0]
+				return &v1.WriteRelationshipsResponse{}, nil
+			},
+		},
+	}
+
+	form := url.Values{
+		"resource": {"resource:doc1"},
+		"relation": {"viewer"},
+		"subject":  {"user:alice"},
+		"op":       {"delete"},
+	}
+	w := httptest.NewRecorder()
+	db.relationshipsHandler(w, newCSRFProtectedPostRequest("/relationships", form))
+
+	if got == nil {
+		t.Fatal("expected WriteRelationships to be called")
+	}
+	if got.Operation != v1.RelationshipUpdate_OPERATION_DELETE {
+		t.Errorf("expected a DELETE operation, got %v", got.Operation)
+	}
+}
+
+func TestRelationshipsHandler_POSTRejectsMalformedObjectRef(t *testing.T) {
+	called := false
+	db := &Dashboard{
+		permissionsClient: &fakePermissionsServiceClient{
+			writeRelationships: func(_ context.Context, _ *v1.WriteRelationshipsRequest) (*v1.WriteRelationshipsResponse, error) {
+				called = true
+				return &v1.WriteRelationshipsResponse{}, nil
+			},
+		},
+	}
+
+	form := url.Values{
+		"resource": {"not-a-valid-ref"},
+		"relation": {"viewer"},
+		"subject":  {"user:alice"},
+	}
+	w := httptest.NewRecorder()
+	db.relationshipsHandler(w, newCSRFProtectedPostRequest("/relationships", form))
+
+	if called {
+		t.Error("expected WriteRelationships not to be called for a malformed object reference")
+	}
+	if !strings.Contains(w.Body.String(), "invalid object reference") {
+		t.Errorf("expected an invalid-reference error in the body, got %q", w.Body.String())
+	}
+}
+
+func TestRelationshipsHandler_NoClientReportsUnavailable(t *testing.T) {
+	db := &Dashboard{}
+
+	w := httptest.NewRecorder()
+	db.relationshipsHandler(w, httptest.NewRequest(http.MethodGet, "/relationships", nil))
+
+	if !strings.Contains(w.Body.String(), "unavailable") {
+		t.Errorf("expected response body to report management is unavailable, got %q", w.Body.String())
+	}
+}
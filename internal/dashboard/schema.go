@@ -0,0 +1,58 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+type schemaPageData struct {
+	Schema string
+	Error  string
+}
+
+// schemaHandler renders the current schema and, on POST, writes a new schema
+// definition back through the SchemaService.
+func (db *Dashboard) schemaHandler(w http.ResponseWriter, r *http.Request) {
+	if db.schemaClient == nil {
+		fmt.Fprint(w, "Schema editor is unavailable: dashboard could not connect to the gRPC endpoint")
+		return
+	}
+
+	var schemaText string
+	var errMessage string
+
+	switch r.Method {
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			errMessage = err.Error()
+			break
+		}
+
+		if err := db.validateCSRF(r); err != nil {
+			errMessage = err.Error()
+			break
+		}
+
+		schemaText = r.FormValue("schema")
+		_, err := db.schemaClient.WriteSchema(r.Context(), &v1.WriteSchemaRequest{
+			Schema: schemaText,
+		})
+		if err != nil {
+			errMessage = err.Error()
+		}
+	default:
+		resp, err := db.schemaClient.ReadSchema(r.Context(), &v1.ReadSchemaRequest{})
+		if err != nil {
+			errMessage = err.Error()
+			break
+		}
+		schemaText = resp.SchemaText
+	}
+
+	db.render(w, r, "schema.html", schemaPageData{
+		Schema: schemaText,
+		Error:  errMessage,
+	})
+}
@@ -0,0 +1,109 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+func TestSchemaHandler_GETRendersCurrentSchema(t *testing.T) {
+	db := &Dashboard{
+		schemaClient: &fakeSchemaServiceClient{
+			readSchema: func(_ context.Context, _ *v1.ReadSchemaRequest) (*v1.ReadSchemaResponse, error) {
+				return &v1.ReadSchemaResponse{SchemaText: "definition user {}"}, nil
+			},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	db.schemaHandler(w, httptest.NewRequest(http.MethodGet, "/schema", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "definition user {}") {
+		t.Errorf("expected response body to contain the schema text, got %q", w.Body.String())
+	}
+}
+
+func TestSchemaHandler_GETReportsReadSchemaError(t *testing.T) {
+	db := &Dashboard{
+		schemaClient: &fakeSchemaServiceClient{
+			readSchema: func(_ context.Context, _ *v1.ReadSchemaRequest) (*v1.ReadSchemaResponse, error) {
+				return nil, errBoom
+			},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	db.schemaHandler(w, httptest.NewRequest(http.MethodGet, "/schema", nil))
+
+	if !strings.Contains(w.Body.String(), errBoom.Error()) {
+		t.Errorf("expected response body to contain the ReadSchema error, got %q", w.Body.String())
+	}
+}
+
+func TestSchemaHandler_POSTWritesSchema(t *testing.T) {
+	var written string
+	db := &Dashboard{
+		schemaClient: &fakeSchemaServiceClient{
+			writeSchema: func(_ context.Context, in *v1.WriteSchemaRequest) (*v1.WriteSchemaResponse, error) {
+				written = in.Schema
+				return &v1.WriteSchemaResponse{}, nil
+			},
+		},
+	}
+
+	form := url.Values{"schema": {"definition resource {}"}}
+	w := httptest.NewRecorder()
+	db.schemaHandler(w, newCSRFProtectedPostRequest("/schema", form))
+
+	if written != "definition resource {}" {
+		t.Errorf("expected WriteSchema to be called with the posted schema, got %q", written)
+	}
+	if strings.Contains(w.Body.String(), errInvalidCSRFToken.Error()) {
+		t.Errorf("expected no CSRF error, got body %q", w.Body.String())
+	}
+}
+
+func TestSchemaHandler_POSTRejectsMissingCSRFToken(t *testing.T) {
+	called := false
+	db := &Dashboard{
+		schemaClient: &fakeSchemaServiceClient{
+			writeSchema: func(_ context.Context, _ *v1.WriteSchemaRequest) (*v1.WriteSchemaResponse, error) {
+				called = true
+				return &v1.WriteSchemaResponse{}, nil
+			},
+		},
+	}
+
+	form := url.Values{"schema": {"definition resource {}"}}
+	r := httptest.NewRequest(http.MethodPost, "/schema", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	db.schemaHandler(w, r)
+
+	if called {
+		t.Error("expected WriteSchema not to be called when the CSRF token is missing")
+	}
+	if !strings.Contains(w.Body.String(), errInvalidCSRFToken.Error()) {
+		t.Errorf("expected response body to report the CSRF error, got %q", w.Body.String())
+	}
+}
+
+func TestSchemaHandler_NoClientReportsUnavailable(t *testing.T) {
+	db := &Dashboard{}
+
+	w := httptest.NewRecorder()
+	db.schemaHandler(w, httptest.NewRequest(http.MethodGet, "/schema", nil))
+
+	if !strings.Contains(w.Body.String(), "unavailable") {
+		t.Errorf("expected response body to report the editor is unavailable, got %q", w.Body.String())
+	}
+}
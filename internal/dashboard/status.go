@@ -0,0 +1,31 @@
+package dashboard
+
+import (
+	"net/http"
+)
+
+// statusRefreshSeconds is how often the /status page reloads itself so an
+// operator can leave it open as a live view.
+const statusRefreshSeconds = 5
+
+type statusPageData struct {
+	Stats Statistics
+	Error string
+}
+
+// statusHandler renders the HealthCollector's most recent probe, refreshing
+// automatically every few seconds so an operator can leave the page open as
+// a live view.
+func (db *Dashboard) statusHandler(w http.ResponseWriter, r *http.Request) {
+	var errMessage string
+
+	stats, err := db.health.Latest()
+	if err != nil {
+		errMessage = err.Error()
+	}
+
+	db.renderWithRefresh(w, r, "status.html", statusPageData{
+		Stats: stats,
+		Error: errMessage,
+	}, statusRefreshSeconds)
+}
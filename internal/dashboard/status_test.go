@@ -0,0 +1,39 @@
+package dashboard
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStatusHandler_RendersLatestStatistics(t *testing.T) {
+	db := &Dashboard{
+		health: &HealthCollector{latest: Statistics{
+			Ready:          true,
+			NamespaceCount: 3,
+		}},
+	}
+
+	w := httptest.NewRecorder()
+	db.statusHandler(w, httptest.NewRequest("GET", "/status", nil))
+
+	if w.Code != 200 {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), errBoom.Error()) {
+		t.Errorf("expected no error in a healthy response, got %q", w.Body.String())
+	}
+}
+
+func TestStatusHandler_RendersProbeError(t *testing.T) {
+	db := &Dashboard{
+		health: &HealthCollector{err: errBoom},
+	}
+
+	w := httptest.NewRecorder()
+	db.statusHandler(w, httptest.NewRequest("GET", "/status", nil))
+
+	if !strings.Contains(w.Body.String(), errBoom.Error()) {
+		t.Errorf("expected the probe error in the body, got %q", w.Body.String())
+	}
+}
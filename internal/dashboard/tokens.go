@@ -0,0 +1,120 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+
+	"github.com/authzed/spicedb/internal/zedtokencache"
+)
+
+type tokensPageData struct {
+	Error      string
+	HasCache   bool
+	Recent     []zedtokencache.CachedToken
+	Comparison *checkComparison
+}
+
+// checkComparison holds the result of running the same CheckPermission call
+// at two different consistency levels, so an operator can see the latency
+// tradeoff of using the cached ZedToken.
+type checkComparison struct {
+	AtLeastAsFresh         string
+	AtLeastAsFreshLatency  time.Duration
+	MinimizeLatency        string
+	MinimizeLatencyLatency time.Duration
+}
+
+// tokensHandler lists the resources with recently cached ZedTokens and, on
+// POST, runs a CheckPermission call against the selected resource at both
+// at_least_as_fresh and minimize_latency consistency so the operator can
+// compare the two.
+func (db *Dashboard) tokensHandler(w http.ResponseWriter, r *http.Request) {
+	var errMessage string
+	var recent []zedtokencache.CachedToken
+	var comparison *checkComparison
+
+	if db.tokenCache != nil {
+		entries, err := db.tokenCache.ListRecent(r.Context(), 50)
+		if err != nil {
+			errMessage = err.Error()
+		}
+		recent = entries
+	}
+
+	if r.Method == http.MethodPost && db.tokenCache != nil && db.permissionsClient != nil {
+		if err := r.ParseForm(); err != nil {
+			errMessage = err.Error()
+		} else if err := db.validateCSRF(r); err != nil {
+			errMessage = err.Error()
+		} else {
+			comparison, errMessage = db.compareCheckLatency(r)
+		}
+	}
+
+	db.render(w, r, "tokens.html", tokensPageData{
+		Error:      errMessage,
+		HasCache:   db.tokenCache != nil,
+		Recent:     recent,
+		Comparison: comparison,
+	})
+}
+
+func (db *Dashboard) compareCheckLatency(r *http.Request) (*checkComparison, string) {
+	namespace := r.FormValue("namespace")
+	objectID := r.FormValue("objectId")
+	permission := r.FormValue("permission")
+
+	subjectType, subjectID, err := parseObjectID(r.FormValue("subject"))
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	resource := &v1.ObjectReference{ObjectType: namespace, ObjectId: objectID}
+	subject := &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: subjectType, ObjectId: subjectID}}
+
+	token, found, err := db.tokenCache.LookupToken(r.Context(), namespace, objectID)
+	if err != nil {
+		return nil, err.Error()
+	}
+	if !found {
+		return nil, fmt.Sprintf("no cached ZedToken for %s:%s", namespace, objectID)
+	}
+
+	start := time.Now()
+	freshResp, err := db.permissionsClient.CheckPermission(r.Context(), &v1.CheckPermissionRequest{
+		Resource:   resource,
+		Permission: permission,
+		Subject:    subject,
+		Consistency: &v1.Consistency{
+			Requirement: &v1.Consistency_AtLeastAsFresh{AtLeastAsFresh: token},
+		},
+	})
+	freshLatency := time.Since(start)
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	start = time.Now()
+	fastResp, err := db.permissionsClient.CheckPermission(r.Context(), &v1.CheckPermissionRequest{
+		Resource:   resource,
+		Permission: permission,
+		Subject:    subject,
+		Consistency: &v1.Consistency{
+			Requirement: &v1.Consistency_MinimizeLatency{MinimizeLatency: true},
+		},
+	})
+	fastLatency := time.Since(start)
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	return &checkComparison{
+		AtLeastAsFresh:         freshResp.Permissionship.String(),
+		AtLeastAsFreshLatency:  freshLatency,
+		MinimizeLatency:        fastResp.Permissionship.String(),
+		MinimizeLatencyLatency: fastLatency,
+	}, ""
+}
@@ -0,0 +1,135 @@
+package dashboard
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+
+	"github.com/authzed/spicedb/internal/zedtokencache"
+)
+
+// fakeTokenCache is a minimal in-memory zedtokencache.ResourceTokenCache for
+// exercising tokensHandler without a real NATS connection.
+type fakeTokenCache struct {
+	recent      []zedtokencache.CachedToken
+	listErr     error
+	lookupToken *v1.ZedToken
+	lookupFound bool
+	lookupErr   error
+}
+
+func (f *fakeTokenCache) RecordToken(_ context.Context, _, _ string, _ *v1.ZedToken) error {
+	return nil
+}
+
+func (f *fakeTokenCache) LookupToken(_ context.Context, _, _ string) (*v1.ZedToken, bool, error) {
+	return f.lookupToken, f.lookupFound, f.lookupErr
+}
+
+func (f *fakeTokenCache) ListRecent(_ context.Context, _ int) ([]zedtokencache.CachedToken, error) {
+	return f.recent, f.listErr
+}
+
+func (f *fakeTokenCache) Close() error { return nil }
+
+func TestTokensHandler_GETListsRecentTokens(t *testing.T) {
+	db := &Dashboard{
+		tokenCache: &fakeTokenCache{
+			recent: []zedtokencache.CachedToken{
+				{Namespace: "resource", ObjectID: "doc1", Token: &v1.ZedToken{Token: "zed-1"}},
+			},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	db.tokensHandler(w, httptest.NewRequest("GET", "/tokens", nil))
+
+	if !strings.Contains(w.Body.String(), "zed-1") {
+		t.Errorf("expected the recorded token in the body, got %q", w.Body.String())
+	}
+}
+
+func TestTokensHandler_GETNoCacheConfigured(t *testing.T) {
+	db := &Dashboard{}
+
+	w := httptest.NewRecorder()
+	db.tokensHandler(w, httptest.NewRequest("GET", "/tokens", nil))
+
+	if w.Code != 200 {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestCompareCheckLatency_NoCachedTokenReportsMissingEntry(t *testing.T) {
+	db := &Dashboard{
+		tokenCache: &fakeTokenCache{lookupFound: false},
+		permissionsClient: &fakePermissionsServiceClient{
+			checkPermission: func(_ context.Context, _ *v1.CheckPermissionRequest) (*v1.CheckPermissionResponse, error) {
+				t.Error("expected CheckPermission not to be called when no token is cached")
+				return nil, errBoom
+			},
+		},
+	}
+
+	form := url.Values{
+		"namespace":  {"resource"},
+		"objectId":   {"doc1"},
+		"permission": {"view"},
+		"subject":    {"user:alice"},
+	}
+	r := httptest.NewRequest("POST", "/tokens", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	comparison, errMessage := db.compareCheckLatency(r)
+	if comparison != nil {
+		t.Errorf("expected no comparison, got %+v", comparison)
+	}
+	if !strings.Contains(errMessage, "no cached ZedToken") {
+		t.Errorf("expected a no-cached-token error, got %q", errMessage)
+	}
+}
+
+func TestCompareCheckLatency_ComparesBothConsistencyLevels(t *testing.T) {
+	var sawConsistency []string
+	db := &Dashboard{
+		tokenCache: &fakeTokenCache{lookupFound: true, lookupToken: &v1.ZedToken{Token: "zed-1"}},
+		permissionsClient: &fakePermissionsServiceClient{
+			checkPermission: func(_ context.Context, in *v1.CheckPermissionRequest) (*v1.CheckPermissionResponse, error) {
+				switch in.Consistency.Requirement.(type) {
+				case *v1.Consistency_AtLeastAsFresh:
+					sawConsistency = append(sawConsistency, "fresh")
+				case *v1.Consistency_MinimizeLatency:
+					sawConsistency = append(sawConsistency, "fast")
+				}
+				return &v1.CheckPermissionResponse{Permissionship: v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION}, nil
+			},
+		},
+	}
+
+	form := url.Values{
+		"namespace":  {"resource"},
+		"objectId":   {"doc1"},
+		"permission": {"view"},
+		"subject":    {"user:alice"},
+	}
+	r := httptest.NewRequest("POST", "/tokens", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	comparison, errMessage := db.compareCheckLatency(r)
+	if errMessage != "" {
+		t.Fatalf("unexpected error: %s", errMessage)
+	}
+	if comparison == nil {
+		t.Fatal("expected a non-nil comparison")
+	}
+	if len(sawConsistency) != 2 || sawConsistency[0] != "fresh" || sawConsistency[1] != "fast" {
+		t.Errorf("expected one at_least_as_fresh call followed by one minimize_latency call, got %v", sawConsistency)
+	}
+	if comparison.AtLeastAsFreshLatency < 0 || comparison.MinimizeLatencyLatency < 0 {
+		t.Errorf("expected non-negative latencies, got %+v", comparison)
+	}
+}
@@ -0,0 +1,112 @@
+// Package zedtokenrecorder provides a gRPC middleware that records the
+// ZedToken returned by relationship and schema writes into a
+// zedtokencache.ResourceTokenCache, so that later reads can be served
+// at_least_as_fresh instead of paying for fully_consistent or guessing with
+// minimize_latency.
+package zedtokenrecorder
+
+import (
+	"context"
+	"regexp"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+
+	"github.com/authzed/spicedb/internal/zedtokencache"
+)
+
+// definitionPattern is a best-effort scan for namespace names touched by a
+// schema write. WriteSchemaResponse only carries the resulting ZedToken, not
+// the namespaces it affected, so rather than pulling in the schema compiler
+// here we just scan for `definition <name>` tokens in the submitted text.
+var definitionPattern = regexp.MustCompile(`(?m)^\s*definition\s+([a-zA-Z0-9_/.]+)`)
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that records
+// the ZedToken from successful WriteRelationships and WriteSchema calls into
+// cache. If cache is nil, the interceptor is a no-op passthrough.
+//
+// This belongs in the main SpiceDB gRPC server's interceptor chain, next to
+// its other unary interceptors, so that every client's writes are recorded
+// rather than only those made through a particular client connection. This
+// package doesn't construct that server, so wiring it in is left to the
+// server setup code that does; internal/dashboard wires ClientInterceptor
+// below into its own client connection instead, which covers writes made
+// through the dashboard's schema editor and relationship manager.
+func UnaryServerInterceptor(cache zedtokencache.ResourceTokenCache) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil || cache == nil {
+			return resp, err
+		}
+
+		switch typedResp := resp.(type) {
+		case *v1.WriteRelationshipsResponse:
+			if typedReq, ok := req.(*v1.WriteRelationshipsRequest); ok {
+				recordRelationshipUpdates(ctx, cache, typedReq.Updates, typedResp.WrittenAt)
+			}
+		case *v1.WriteSchemaResponse:
+			if typedReq, ok := req.(*v1.WriteSchemaRequest); ok {
+				recordSchemaWrite(ctx, cache, typedReq.Schema, typedResp.WrittenAt)
+			}
+		}
+
+		return resp, err
+	}
+}
+
+// ClientInterceptor returns a grpc.UnaryClientInterceptor that records the
+// ZedToken from successful WriteRelationships and WriteSchema calls made
+// over the client connection it's attached to. It shares its recording
+// logic with UnaryServerInterceptor; use that one instead when wiring up
+// the main gRPC server, since it records every client's writes rather than
+// only those made through one connection. If cache is nil, the interceptor
+// is a no-op passthrough.
+func ClientInterceptor(cache zedtokencache.ResourceTokenCache) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, resp interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, resp, cc, opts...)
+		if err != nil || cache == nil {
+			return err
+		}
+
+		switch typedResp := resp.(type) {
+		case *v1.WriteRelationshipsResponse:
+			if typedReq, ok := req.(*v1.WriteRelationshipsRequest); ok {
+				recordRelationshipUpdates(ctx, cache, typedReq.Updates, typedResp.WrittenAt)
+			}
+		case *v1.WriteSchemaResponse:
+			if typedReq, ok := req.(*v1.WriteSchemaRequest); ok {
+				recordSchemaWrite(ctx, cache, typedReq.Schema, typedResp.WrittenAt)
+			}
+		}
+
+		return nil
+	}
+}
+
+func recordRelationshipUpdates(ctx context.Context, cache zedtokencache.ResourceTokenCache, updates []*v1.RelationshipUpdate, token *v1.ZedToken) {
+	for _, update := range updates {
+		resource := update.GetRelationship().GetResource()
+		if resource == nil {
+			continue
+		}
+
+		if err := cache.RecordToken(ctx, resource.ObjectType, resource.ObjectId, token); err != nil {
+			log.Warn().Err(err).Str("namespace", resource.ObjectType).Str("objectId", resource.ObjectId).
+				Msg("failed to record ZedToken for written relationship")
+		}
+	}
+}
+
+func recordSchemaWrite(ctx context.Context, cache zedtokencache.ResourceTokenCache, schemaText string, token *v1.ZedToken) {
+	for _, match := range definitionPattern.FindAllStringSubmatch(schemaText, -1) {
+		namespace := match[1]
+
+		// Schema writes aren't scoped to a single object, so we record
+		// against a "*" sentinel object ID standing for the entire
+		// namespace; the token inspector lists it with object ID "*".
+		if err := cache.RecordToken(ctx, namespace, "*", token); err != nil {
+			log.Warn().Err(err).Str("namespace", namespace).Msg("failed to record ZedToken for written schema")
+		}
+	}
+}
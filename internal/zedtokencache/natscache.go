@@ -0,0 +1,176 @@
+package zedtokencache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+)
+
+// Config configures a NATS JetStream KV-backed ResourceTokenCache.
+type Config struct {
+	// NatsURL is the URL of the NATS server hosting the JetStream KV bucket.
+	NatsURL string
+
+	// BucketName is the JetStream KV bucket used to store cached tokens. It
+	// is created if it does not already exist.
+	BucketName string
+
+	// TTL is how long a recorded token is kept before NATS evicts it. This
+	// should be configured to be greater than or equal to the datastore's
+	// revision quantization interval, so that entries don't expire before
+	// other SpiceDB frontends have caught up to the revision they name.
+	TTL time.Duration
+}
+
+// cacheEntry is the JSON-encoded value stored for each KV key.
+type cacheEntry struct {
+	Token      string    `json:"token"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+type natsCache struct {
+	conn *nats.Conn
+	kv   nats.KeyValue
+	ttl  time.Duration
+}
+
+// NewNATSCache creates a ResourceTokenCache backed by a NATS JetStream KV
+// bucket. If the NATS server cannot be reached or the bucket cannot be
+// created, an error is returned; callers should treat this the same as any
+// other unavailable cache and fall back to not using one.
+func NewNATSCache(cfg Config) (ResourceTokenCache, error) {
+	conn, err := nats.Connect(cfg.NatsURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to NATS: %w", err)
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to create JetStream context: %w", err)
+	}
+
+	kv, err := js.KeyValue(cfg.BucketName)
+	if err != nil {
+		kv, err = js.CreateKeyValue(&nats.KeyValueConfig{
+			Bucket: cfg.BucketName,
+			TTL:    cfg.TTL,
+		})
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("unable to create KV bucket %q: %w", cfg.BucketName, err)
+		}
+	}
+
+	return &natsCache{conn: conn, kv: kv, ttl: cfg.TTL}, nil
+}
+
+func cacheKey(namespace, objectID string) string {
+	// NATS KV keys may not contain '.', and ListRecent splits the key back
+	// into namespace/objectID on the first '/', so both characters must be
+	// stripped from each segment before joining them: SpiceDB namespace
+	// names may legitimately contain '/' as a tenant-prefix delimiter (e.g.
+	// "tenant/document"), and leaving it in would make that split ambiguous.
+	sanitize := func(s string) string {
+		s = strings.ReplaceAll(s, ".", "_")
+		return strings.ReplaceAll(s, "/", "_")
+	}
+	return sanitize(namespace) + "/" + sanitize(objectID)
+}
+
+func (c *natsCache) RecordToken(_ context.Context, namespace, objectID string, token *v1.ZedToken) error {
+	entry := cacheEntry{Token: token.Token, ObservedAt: time.Now()}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.kv.Put(cacheKey(namespace, objectID), data); err != nil {
+		// Recording is best-effort: if NATS is unreachable, log and move on
+		// rather than failing the write that triggered this call.
+		log.Warn().Err(err).Str("namespace", namespace).Str("objectId", objectID).
+			Msg("failed to record ZedToken in NATS KV cache")
+		return nil
+	}
+
+	return nil
+}
+
+func (c *natsCache) LookupToken(_ context.Context, namespace, objectID string) (*v1.ZedToken, bool, error) {
+	entry, err := c.kv.Get(cacheKey(namespace, objectID))
+	if err != nil {
+		// Missing key, expired TTL, or an unreachable NATS server are all
+		// treated the same: no cached token, exactly as if none had ever
+		// been recorded.
+		return nil, false, nil
+	}
+
+	var decoded cacheEntry
+	if err := json.Unmarshal(entry.Value(), &decoded); err != nil {
+		return nil, false, nil
+	}
+
+	return &v1.ZedToken{Token: decoded.Token}, true, nil
+}
+
+func (c *natsCache) ListRecent(_ context.Context, limit int) ([]CachedToken, error) {
+	keys, err := c.kv.Keys()
+	if err != nil {
+		// An unreachable NATS server just means an empty inspector view.
+		return nil, nil
+	}
+
+	entries := make([]CachedToken, 0, len(keys))
+	for _, key := range keys {
+		kvEntry, err := c.kv.Get(key)
+		if err != nil {
+			continue
+		}
+
+		var decoded cacheEntry
+		if err := json.Unmarshal(kvEntry.Value(), &decoded); err != nil {
+			continue
+		}
+
+		namespace, objectID, ok := strings.Cut(key, "/")
+		if !ok {
+			continue
+		}
+
+		ttlRemaining := c.ttl - time.Since(decoded.ObservedAt)
+		if ttlRemaining < 0 {
+			ttlRemaining = 0
+		}
+
+		entries = append(entries, CachedToken{
+			Namespace:    namespace,
+			ObjectID:     objectID,
+			Token:        &v1.ZedToken{Token: decoded.Token},
+			ObservedAt:   decoded.ObservedAt,
+			TTLRemaining: ttlRemaining,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ObservedAt.After(entries[j].ObservedAt)
+	})
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+func (c *natsCache) Close() error {
+	c.conn.Close()
+	return nil
+}
@@ -0,0 +1,182 @@
+package zedtokencache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/nats-io/nats.go"
+)
+
+// fakeKV is an in-memory stand-in for nats.KeyValue, embedding the real
+// interface so it only needs to implement the methods natsCache actually
+// calls; anything else panics via the nil embedded interface if exercised.
+type fakeKV struct {
+	nats.KeyValue
+	entries map[string][]byte
+
+	putErr  error
+	keysErr error
+}
+
+func newFakeKV() *fakeKV {
+	return &fakeKV{entries: make(map[string][]byte)}
+}
+
+func (f *fakeKV) Put(key string, value []byte) (uint64, error) {
+	if f.putErr != nil {
+		return 0, f.putErr
+	}
+	f.entries[key] = value
+	return 1, nil
+}
+
+func (f *fakeKV) Get(key string) (nats.KeyValueEntry, error) {
+	value, ok := f.entries[key]
+	if !ok {
+		return nil, nats.ErrKeyNotFound
+	}
+	return &fakeEntry{value: value}, nil
+}
+
+func (f *fakeKV) Keys(_ ...nats.WatchOpt) ([]string, error) {
+	if f.keysErr != nil {
+		return nil, f.keysErr
+	}
+	keys := make([]string, 0, len(f.entries))
+	for k := range f.entries {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+type fakeEntry struct {
+	nats.KeyValueEntry
+	value []byte
+}
+
+func (f *fakeEntry) Value() []byte { return f.value }
+
+func putEntry(t *testing.T, kv *fakeKV, key string, entry cacheEntry) {
+	t.Helper()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	kv.entries[key] = data
+}
+
+func TestNatsCache_RecordAndLookupToken(t *testing.T) {
+	kv := newFakeKV()
+	cache := &natsCache{kv: kv, ttl: time.Hour}
+
+	err := cache.RecordToken(context.Background(), "resource", "sampleresource", &v1.ZedToken{Token: "zed-1"})
+	if err != nil {
+		t.Fatalf("RecordToken returned error: %v", err)
+	}
+
+	token, found, err := cache.LookupToken(context.Background(), "resource", "sampleresource")
+	if err != nil {
+		t.Fatalf("LookupToken returned error: %v", err)
+	}
+	if !found {
+		t.Fatal("expected LookupToken to find the recorded token")
+	}
+	if token.Token != "zed-1" {
+		t.Errorf("expected token %q, got %q", "zed-1", token.Token)
+	}
+}
+
+func TestNatsCache_RecordToken_FallsBackSilentlyWhenNATSUnreachable(t *testing.T) {
+	kv := newFakeKV()
+	kv.putErr = errors.New("connection refused")
+	cache := &natsCache{kv: kv, ttl: time.Hour}
+
+	// RecordToken is called inline with a write that already succeeded; an
+	// unreachable cache must not turn that into a failed request.
+	if err := cache.RecordToken(context.Background(), "resource", "id", &v1.ZedToken{Token: "zed-1"}); err != nil {
+		t.Errorf("expected RecordToken to swallow the NATS error, got %v", err)
+	}
+}
+
+func TestNatsCache_LookupToken_MissReturnsNotFoundRatherThanError(t *testing.T) {
+	cache := &natsCache{kv: newFakeKV(), ttl: time.Hour}
+
+	token, found, err := cache.LookupToken(context.Background(), "resource", "missing")
+	if err != nil {
+		t.Fatalf("expected no error on cache miss, got %v", err)
+	}
+	if found {
+		t.Error("expected found=false for a missing key")
+	}
+	if token != nil {
+		t.Error("expected a nil token for a missing key")
+	}
+}
+
+func TestNatsCache_ListRecent_OrdersByMostRecentAndAppliesTTL(t *testing.T) {
+	kv := newFakeKV()
+	cache := &natsCache{kv: kv, ttl: time.Hour}
+
+	now := time.Now()
+	putEntry(t, kv, "resource/older", cacheEntry{Token: "older", ObservedAt: now.Add(-2 * time.Hour)})
+	putEntry(t, kv, "resource/newer", cacheEntry{Token: "newer", ObservedAt: now})
+
+	recent, err := cache.ListRecent(context.Background(), 50)
+	if err != nil {
+		t.Fatalf("ListRecent returned error: %v", err)
+	}
+	if len(recent) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(recent))
+	}
+	if recent[0].Token.Token != "newer" {
+		t.Errorf("expected the newest entry first, got %q", recent[0].Token.Token)
+	}
+
+	// The "older" entry was recorded before the TTL elapsed relative to
+	// "now", so its TTL remaining should have been clamped to zero, not
+	// gone negative.
+	for _, entry := range recent {
+		if entry.Namespace != "resource" {
+			t.Errorf("expected namespace %q, got %q", "resource", entry.Namespace)
+		}
+		if entry.TTLRemaining < 0 {
+			t.Errorf("expected TTLRemaining to be clamped at zero, got %v", entry.TTLRemaining)
+		}
+	}
+}
+
+func TestNatsCache_ListRecent_RespectsLimit(t *testing.T) {
+	kv := newFakeKV()
+	cache := &natsCache{kv: kv, ttl: time.Hour}
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		putEntry(t, kv, "resource/"+string(rune('a'+i)), cacheEntry{Token: "t", ObservedAt: now})
+	}
+
+	recent, err := cache.ListRecent(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("ListRecent returned error: %v", err)
+	}
+	if len(recent) != 2 {
+		t.Errorf("expected ListRecent to cap at the requested limit of 2, got %d", len(recent))
+	}
+}
+
+func TestNatsCache_ListRecent_FallsBackToEmptyWhenNATSUnreachable(t *testing.T) {
+	kv := newFakeKV()
+	kv.keysErr = errors.New("connection refused")
+	cache := &natsCache{kv: kv, ttl: time.Hour}
+
+	recent, err := cache.ListRecent(context.Background(), 50)
+	if err != nil {
+		t.Errorf("expected ListRecent to swallow the NATS error, got %v", err)
+	}
+	if recent != nil {
+		t.Errorf("expected a nil/empty slice, got %v", recent)
+	}
+}
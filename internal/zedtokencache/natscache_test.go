@@ -0,0 +1,50 @@
+package zedtokencache
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCacheKey_SanitizesDots(t *testing.T) {
+	cases := []struct {
+		namespace, objectID, want string
+	}{
+		{"resource", "sampleresource", "resource/sampleresource"},
+		{"example.namespace", "some.object", "example_namespace/some_object"},
+		{"a.b.c", "d.e.f", "a_b_c/d_e_f"},
+	}
+
+	for _, c := range cases {
+		if got := cacheKey(c.namespace, c.objectID); got != c.want {
+			t.Errorf("cacheKey(%q, %q) = %q, want %q", c.namespace, c.objectID, got, c.want)
+		}
+	}
+}
+
+func TestCacheKey_SanitizesSlashesSoTheJoinSeparatorStaysUnambiguous(t *testing.T) {
+	// ListRecent splits a key back into namespace/objectID on the first
+	// "/", so cacheKey must never let either segment contribute one of its
+	// own - including a namespace using "/" as a tenant-prefix delimiter,
+	// e.g. "tenant/document".
+	cases := []struct {
+		namespace, objectID, want string
+	}{
+		{"tenant/document", "42", "tenant_document/42"},
+		{"resource", "id/with/slashes", "resource/id_with_slashes"},
+	}
+
+	for _, c := range cases {
+		key := cacheKey(c.namespace, c.objectID)
+		if key != c.want {
+			t.Fatalf("cacheKey(%q, %q) = %q, want %q", c.namespace, c.objectID, key, c.want)
+		}
+
+		namespace, objectID, ok := strings.Cut(key, "/")
+		if !ok {
+			t.Fatalf("cacheKey(%q, %q) = %q has no separator to split on", c.namespace, c.objectID, key)
+		}
+		if strings.Contains(namespace, "/") || strings.Contains(objectID, "/") {
+			t.Fatalf("cacheKey(%q, %q) = %q: split on first '/' still leaves a '/' in a segment", c.namespace, c.objectID, key)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Package zedtokencache provides a pluggable cache of the most recently
+// observed ZedToken for a given resource, so that operator tooling (such as
+// the dashboard's token inspector) can show how fresh a resource's cached
+// state is and compare check latencies at different consistency levels.
+package zedtokencache
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+// CachedToken is a single entry returned from a ResourceTokenCache's
+// ListRecent call.
+type CachedToken struct {
+	// Namespace is the object type the token was recorded against.
+	Namespace string
+
+	// ObjectID is the object ID the token was recorded against.
+	ObjectID string
+
+	// Token is the most recently observed ZedToken for the resource.
+	Token *v1.ZedToken
+
+	// ObservedAt is when the token was recorded.
+	ObservedAt time.Time
+
+	// TTLRemaining is how much longer the entry will live in the cache.
+	TTLRemaining time.Duration
+}
+
+// ResourceTokenCache records the latest ZedToken observed for a
+// (namespace, object_id) pair after a write, so that later reads can use it
+// to request an at_least_as_fresh consistency level instead of
+// fully_consistent or minimize_latency.
+//
+// Implementations must fall back cleanly when their backing store is
+// unreachable: LookupToken should return (nil, false, nil), as if no token
+// had ever been recorded, rather than an error that would force callers to
+// fail open or closed themselves.
+type ResourceTokenCache interface {
+	// RecordToken stores token as the latest observed ZedToken for the given
+	// resource.
+	RecordToken(ctx context.Context, namespace, objectID string, token *v1.ZedToken) error
+
+	// LookupToken returns the most recently recorded ZedToken for the given
+	// resource, if any is cached and has not expired.
+	LookupToken(ctx context.Context, namespace, objectID string) (token *v1.ZedToken, found bool, err error)
+
+	// ListRecent returns up to limit of the most recently recorded tokens,
+	// newest first.
+	ListRecent(ctx context.Context, limit int) ([]CachedToken, error)
+
+	// Close releases any resources held by the cache.
+	Close() error
+}